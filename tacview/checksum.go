@@ -0,0 +1,77 @@
+package tacview
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"strings"
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+type ChecksumError struct {
+	Offset float64
+	Want   uint32
+	Got    uint32
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("tacview: checksum mismatch at offset %v: want %08x, got %08x", e.Offset, e.Want, e.Got)
+}
+
+// The CRC trailer is hashed over the object lines only, not the leading
+// "#<offset>" line, matching what readChecksumTrailer rolls forward.
+func (w *Writer) WriteTimeFrameChecksummed(tf *TimeFrame) error {
+	var buf bytes.Buffer
+	bw := bufio.NewWriter(&buf)
+	if err := tf.Write(bw, false); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	if w.index != nil {
+		if err := w.writer.Flush(); err != nil {
+			return err
+		}
+		w.index.Entries = append(w.index.Entries, IndexEntry{Offset: tf.Offset, BytePos: w.counter.n})
+	}
+
+	if err := tf.writeOffsetLine(w.writer); err != nil {
+		return err
+	}
+
+	if _, err := w.writer.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	w.lastCRC = crc32.Update(w.lastCRC, crcTable, buf.Bytes())
+
+	_, err := w.writer.WriteString(fmt.Sprintf("// CRC=%08x\n", w.lastCRC))
+	return err
+}
+
+func (r *Reader) readChecksumTrailer(reader *bufio.Reader, offset float64, body []byte) error {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+
+	var want uint32
+	if _, serr := fmt.Sscanf(strings.TrimSuffix(line, "\n"), "// CRC=%08x", &want); serr != nil {
+		return nil
+	}
+
+	r.crcMu.Lock()
+	got := crc32.Update(r.lastCRC, crcTable, body)
+	r.lastCRC = got
+	r.crcMu.Unlock()
+
+	if r.VerifyChecksums && got != want {
+		return &ChecksumError{Offset: offset, Want: want, Got: got}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package tacview
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+type nopWriteCloser struct {
+	*bytes.Buffer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestWriteTimeFrameChecksummedRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	header := &Header{InitialTimeFrame: *NewTimeFrame()}
+	header.InitialTimeFrame.Objects = append(header.InitialTimeFrame.Objects, &Object{
+		Id:         0,
+		Properties: []*Property{{Key: "ReferenceTime", Value: "2020-01-01T00:00:00Z"}},
+	})
+
+	w, err := NewWriter(nopWriteCloser{&buf}, header)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	frames := []*TimeFrame{
+		{Offset: 1, Objects: []*Object{{Id: 1, Properties: []*Property{{Key: "T", Value: "1|2|3"}}}}},
+		{Offset: 2, Objects: []*Object{{Id: 1, Properties: []*Property{{Key: "T", Value: "4|5|6"}}}}},
+	}
+	for _, tf := range frames {
+		if err := w.WriteTimeFrameChecksummed(tf); err != nil {
+			t.Fatalf("WriteTimeFrameChecksummed: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	r.VerifyChecksums = true
+
+	for i := range frames {
+		tf, err := r.ReadTimeFrame()
+		if err != nil && err != io.EOF {
+			t.Fatalf("ReadTimeFrame %d: %v", i, err)
+		}
+		if tf.Offset != frames[i].Offset {
+			t.Errorf("frame %d: got offset %v, want %v", i, tf.Offset, frames[i].Offset)
+		}
+	}
+}
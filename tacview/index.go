@@ -0,0 +1,155 @@
+package tacview
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+type IndexEntry struct {
+	Offset  float64
+	BytePos int64
+}
+
+type Index struct {
+	Entries []IndexEntry
+}
+
+func BuildIndex(r io.Reader) (*Index, error) {
+	br := bufio.NewReader(r)
+	idx := &Index{}
+
+	var pos int64
+	for {
+		line, err := br.ReadBytes('\n')
+
+		if len(line) > 1 && line[0] == '#' {
+			if offset, perr := strconv.ParseFloat(string(line[1:len(line)-1]), 64); perr == nil {
+				idx.Entries = append(idx.Entries, IndexEntry{Offset: offset, BytePos: pos})
+			}
+		}
+
+		pos += int64(len(line))
+
+		if err != nil {
+			if err == io.EOF {
+				return idx, nil
+			}
+			return nil, err
+		}
+	}
+}
+
+func (idx *Index) Lookup(seconds float64) (int64, bool) {
+	i := sort.Search(len(idx.Entries), func(i int) bool {
+		return idx.Entries[i].Offset > seconds
+	})
+	if i == 0 {
+		return 0, false
+	}
+	return idx.Entries[i-1].BytePos, true
+}
+
+func (idx *Index) WriteTo(w io.Writer) (int64, error) {
+	sort.Slice(idx.Entries, func(i, j int) bool {
+		return idx.Entries[i].Offset < idx.Entries[j].Offset
+	})
+
+	var buf bytes.Buffer
+	for _, e := range idx.Entries {
+		if err := binary.Write(&buf, binary.LittleEndian, e.Offset); err != nil {
+			return 0, err
+		}
+		if err := binary.Write(&buf, binary.LittleEndian, e.BytePos); err != nil {
+			return 0, err
+		}
+	}
+
+	sum := sha1.Sum(buf.Bytes())
+	buf.Write(sum[:])
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+func (idx *Index) ReadFrom(r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return int64(len(data)), err
+	}
+
+	if len(data) < sha1.Size {
+		return int64(len(data)), fmt.Errorf("Index is too short to contain a checksum")
+	}
+
+	body, trailer := data[:len(data)-sha1.Size], data[len(data)-sha1.Size:]
+	if sum := sha1.Sum(body); !bytes.Equal(sum[:], trailer) {
+		return int64(len(data)), fmt.Errorf("Index checksum mismatch, index is stale")
+	}
+
+	const recordSize = 16
+	if len(body)%recordSize != 0 {
+		return int64(len(data)), fmt.Errorf("Corrupt index, length is not a multiple of %d", recordSize)
+	}
+
+	entries := make([]IndexEntry, 0, len(body)/recordSize)
+	br := bytes.NewReader(body)
+	for br.Len() > 0 {
+		var e IndexEntry
+		if err := binary.Read(br, binary.LittleEndian, &e.Offset); err != nil {
+			return int64(len(data)), err
+		}
+		if err := binary.Read(br, binary.LittleEndian, &e.BytePos); err != nil {
+			return int64(len(data)), err
+		}
+		entries = append(entries, e)
+	}
+
+	idx.Entries = entries
+	return int64(len(data)), nil
+}
+
+func SidecarIndexPath(acmiPath string) string {
+	return acmiPath + ".idx"
+}
+
+// UseIndex requires r to be backed by an io.ReaderAt.
+func (r *Reader) UseIndex(idx *Index) {
+	r.index = idx
+}
+
+func (r *Reader) SeekToOffset(seconds float64) error {
+	if r.index == nil {
+		return errors.New("No index attached, call UseIndex first")
+	}
+	if r.ra == nil {
+		return errors.New("Reader is not backed by an io.ReaderAt, cannot seek")
+	}
+
+	bytePos, ok := r.index.Lookup(seconds)
+	if !ok {
+		return fmt.Errorf("No indexed time frame at or before %v seconds", seconds)
+	}
+
+	r.reader = bufio.NewReader(io.NewSectionReader(r.ra, bytePos, 1<<62))
+	return nil
+}
+
+func (r *Reader) ReadTimeFrameAt(seconds float64) (*TimeFrame, error) {
+	if err := r.SeekToOffset(seconds); err != nil {
+		return nil, err
+	}
+
+	tf := NewTimeFrame()
+	if err := r.readTimeFrame(r.reader, tf, true); err != nil {
+		return nil, err
+	}
+
+	return tf, nil
+}
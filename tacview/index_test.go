@@ -0,0 +1,108 @@
+package tacview
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestIndexWriteToReadFromRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []IndexEntry
+	}{
+		{"empty", nil},
+		{"one entry", []IndexEntry{{Offset: 1, BytePos: 10}}},
+		{"unsorted entries", []IndexEntry{{Offset: 3, BytePos: 30}, {Offset: 1, BytePos: 10}, {Offset: 2, BytePos: 20}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := &Index{Entries: tt.entries}
+
+			var buf bytes.Buffer
+			if _, err := idx.WriteTo(&buf); err != nil {
+				t.Fatalf("WriteTo: %v", err)
+			}
+
+			var got Index
+			if _, err := got.ReadFrom(&buf); err != nil {
+				t.Fatalf("ReadFrom: %v", err)
+			}
+
+			if len(got.Entries) != len(idx.Entries) {
+				t.Fatalf("got %d entries, want %d", len(got.Entries), len(idx.Entries))
+			}
+			for i, e := range got.Entries {
+				if e != idx.Entries[i] {
+					t.Errorf("entry %d: got %+v, want %+v", i, e, idx.Entries[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIndexReadFromRejectsStaleChecksum(t *testing.T) {
+	idx := &Index{Entries: []IndexEntry{{Offset: 1, BytePos: 10}}}
+
+	var buf bytes.Buffer
+	if _, err := idx.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	corrupted := buf.Bytes()
+	corrupted[0] ^= 0xff
+
+	var got Index
+	if _, err := got.ReadFrom(bytes.NewReader(corrupted)); err == nil {
+		t.Fatal("ReadFrom accepted a corrupted index")
+	}
+}
+
+func TestBuildIndexAndSeekToOffset(t *testing.T) {
+	header := &Header{InitialTimeFrame: *NewTimeFrame()}
+	header.InitialTimeFrame.Objects = append(header.InitialTimeFrame.Objects, &Object{
+		Id:         0,
+		Properties: []*Property{{Key: "ReferenceTime", Value: "2020-01-01T00:00:00Z"}},
+	})
+
+	var buf bytes.Buffer
+	w, err := NewWriter(nopWriteCloser{&buf}, header)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	frames := []*TimeFrame{
+		{Offset: 1, Objects: []*Object{{Id: 1, Properties: []*Property{{Key: "T", Value: "1|2|3"}}}}},
+		{Offset: 2, Objects: []*Object{{Id: 1, Properties: []*Property{{Key: "T", Value: "4|5|6"}}}}},
+		{Offset: 3, Objects: []*Object{{Id: 1, Properties: []*Property{{Key: "T", Value: "7|8|9"}}}}},
+	}
+	for _, tf := range frames {
+		if err := w.WriteTimeFrame(tf); err != nil {
+			t.Fatalf("WriteTimeFrame: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	idx, err := BuildIndex(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("BuildIndex: %v", err)
+	}
+	if len(idx.Entries) != len(frames) {
+		t.Fatalf("got %d index entries, want %d", len(idx.Entries), len(frames))
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	r.UseIndex(idx)
+
+	tf, err := r.ReadTimeFrameAt(2)
+	if err != nil {
+		t.Fatalf("ReadTimeFrameAt: %v", err)
+	}
+	if tf.Offset != 2 {
+		t.Errorf("got offset %v, want 2", tf.Offset)
+	}
+}
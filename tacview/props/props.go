@@ -0,0 +1,183 @@
+package props
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NanoTrasen-Inc/jambon/tacview"
+)
+
+// A nil field means "unchanged since the last frame" (see Merge), not zero.
+type Transform struct {
+	Longitude *float64
+	Latitude  *float64
+	Altitude  *float64
+	U         *float64
+	V         *float64
+	Heading   *float64
+	Roll      *float64
+	Pitch     *float64
+	Yaw       *float64
+}
+
+func (t *Transform) slots() [9]**float64 {
+	return [9]**float64{
+		&t.Longitude, &t.Latitude, &t.Altitude,
+		&t.U, &t.V, &t.Heading,
+		&t.Roll, &t.Pitch, &t.Yaw,
+	}
+}
+
+func (t Transform) values() [9]*float64 {
+	return [9]*float64{
+		t.Longitude, t.Latitude, t.Altitude,
+		t.U, t.V, t.Heading,
+		t.Roll, t.Pitch, t.Yaw,
+	}
+}
+
+func ParseTransform(raw string) (Transform, error) {
+	fields := strings.Split(raw, "|")
+	if len(fields) > 9 {
+		return Transform{}, fmt.Errorf("T= has %d fields, expected at most 9", len(fields))
+	}
+
+	var t Transform
+	slots := t.slots()
+	for i, field := range fields {
+		if field == "" {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return Transform{}, fmt.Errorf("Invalid T= field %d (%q): %w", i, field, err)
+		}
+
+		*slots[i] = &v
+	}
+
+	return t, nil
+}
+
+func (t Transform) String() string {
+	values := t.values()
+
+	last := -1
+	for i, v := range values {
+		if v != nil {
+			last = i
+		}
+	}
+
+	parts := make([]string, last+1)
+	for i := 0; i <= last; i++ {
+		if values[i] != nil {
+			parts[i] = strconv.FormatFloat(*values[i], 'f', -1, 64)
+		}
+	}
+
+	return strings.Join(parts, "|")
+}
+
+func Merge(prev, next Transform) Transform {
+	prevValues := prev.values()
+	nextValues := next.values()
+
+	var out Transform
+	outSlots := out.slots()
+	for i := 0; i < 9; i++ {
+		if nextValues[i] != nil {
+			v := *nextValues[i]
+			*outSlots[i] = &v
+		} else if prevValues[i] != nil {
+			v := *prevValues[i]
+			*outSlots[i] = &v
+		}
+	}
+
+	return out
+}
+
+func Get(o *tacview.Object) (Transform, bool) {
+	p := o.Get("T")
+	if p == nil {
+		return Transform{}, false
+	}
+
+	t, err := ParseTransform(p.Value)
+	if err != nil {
+		return Transform{}, false
+	}
+
+	return t, true
+}
+
+func Set(o *tacview.Object, t Transform) {
+	o.Set("T", t.String())
+}
+
+func stringProp(o *tacview.Object, key string) string {
+	p := o.Get(key)
+	if p == nil {
+		return ""
+	}
+	return p.Value
+}
+
+func floatProp(o *tacview.Object, key string) (float64, bool) {
+	p := o.Get(key)
+	if p == nil {
+		return 0, false
+	}
+
+	v, err := strconv.ParseFloat(p.Value, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return v, true
+}
+
+func Type(o *tacview.Object) string      { return stringProp(o, "Type") }
+func Name(o *tacview.Object) string      { return stringProp(o, "Name") }
+func Coalition(o *tacview.Object) string { return stringProp(o, "Coalition") }
+func Color(o *tacview.Object) string     { return stringProp(o, "Color") }
+func Pilot(o *tacview.Object) string     { return stringProp(o, "Pilot") }
+func Group(o *tacview.Object) string     { return stringProp(o, "Group") }
+
+func IAS(o *tacview.Object) (float64, bool)      { return floatProp(o, "IAS") }
+func TAS(o *tacview.Object) (float64, bool)      { return floatProp(o, "TAS") }
+func Mach(o *tacview.Object) (float64, bool)     { return floatProp(o, "Mach") }
+func AOA(o *tacview.Object) (float64, bool)      { return floatProp(o, "AOA") }
+func Throttle(o *tacview.Object) (float64, bool) { return floatProp(o, "Throttle") }
+
+type State struct {
+	transforms map[uint64]Transform
+}
+
+func NewState() *State {
+	return &State{transforms: make(map[uint64]Transform)}
+}
+
+func (s *State) Apply(tf *tacview.TimeFrame) {
+	for _, o := range tf.Objects {
+		if o.Deleted {
+			delete(s.transforms, o.Id)
+			continue
+		}
+
+		next, ok := Get(o)
+		if !ok {
+			continue
+		}
+
+		s.transforms[o.Id] = Merge(s.transforms[o.Id], next)
+	}
+}
+
+func (s *State) At(id uint64) (Transform, bool) {
+	t, ok := s.transforms[id]
+	return t, ok
+}
@@ -0,0 +1,101 @@
+package props
+
+import (
+	"testing"
+
+	"github.com/NanoTrasen-Inc/jambon/tacview"
+)
+
+func f(v float64) *float64 { return &v }
+
+func TestParseTransformString(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want Transform
+	}{
+		{"empty", "", Transform{}},
+		{"3 fields", "1|2|3", Transform{Longitude: f(1), Latitude: f(2), Altitude: f(3)}},
+		{"interior field omitted", "1||3", Transform{Longitude: f(1), Altitude: f(3)}},
+		{"9 fields", "1|2|3|4|5|6|7|8|9", Transform{
+			Longitude: f(1), Latitude: f(2), Altitude: f(3),
+			U: f(4), V: f(5), Heading: f(6),
+			Roll: f(7), Pitch: f(8), Yaw: f(9),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTransform(tt.raw)
+			if err != nil {
+				t.Fatalf("ParseTransform(%q): %v", tt.raw, err)
+			}
+
+			gotValues, wantValues := got.values(), tt.want.values()
+			for i := range gotValues {
+				gv, wv := gotValues[i], wantValues[i]
+				if (gv == nil) != (wv == nil) || (gv != nil && *gv != *wv) {
+					t.Errorf("field %d: got %v, want %v", i, gv, wv)
+				}
+			}
+
+			if roundTripped := got.String(); roundTripped != tt.raw {
+				t.Errorf("String() = %q, want %q", roundTripped, tt.raw)
+			}
+		})
+	}
+}
+
+func TestParseTransformTooManyFields(t *testing.T) {
+	if _, err := ParseTransform("1|2|3|4|5|6|7|8|9|10"); err == nil {
+		t.Fatal("ParseTransform accepted 10 fields")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	prev := Transform{Longitude: f(1), Latitude: f(2), Altitude: f(3)}
+	next := Transform{Latitude: f(20)}
+
+	got := Merge(prev, next)
+
+	if *got.Longitude != 1 {
+		t.Errorf("Longitude = %v, want unchanged 1", *got.Longitude)
+	}
+	if *got.Latitude != 20 {
+		t.Errorf("Latitude = %v, want updated 20", *got.Latitude)
+	}
+	if *got.Altitude != 3 {
+		t.Errorf("Altitude = %v, want unchanged 3", *got.Altitude)
+	}
+}
+
+func TestStateApply(t *testing.T) {
+	s := NewState()
+
+	o := &tacview.Object{Id: 1, Properties: []*tacview.Property{{Key: "T", Value: "1|2|3"}}}
+	s.Apply(&tacview.TimeFrame{Objects: []*tacview.Object{o}})
+
+	got, ok := s.At(1)
+	if !ok {
+		t.Fatal("At(1) not found after first Apply")
+	}
+	if *got.Longitude != 1 || *got.Latitude != 2 || *got.Altitude != 3 {
+		t.Errorf("got %+v, want Longitude=1 Latitude=2 Altitude=3", got)
+	}
+
+	update := &tacview.Object{Id: 1, Properties: []*tacview.Property{{Key: "T", Value: "||30"}}}
+	s.Apply(&tacview.TimeFrame{Objects: []*tacview.Object{update}})
+
+	got, ok = s.At(1)
+	if !ok {
+		t.Fatal("At(1) not found after second Apply")
+	}
+	if *got.Longitude != 1 || *got.Latitude != 2 || *got.Altitude != 30 {
+		t.Errorf("after sparse update, got %+v, want Longitude=1 Latitude=2 Altitude=30", got)
+	}
+
+	s.Apply(&tacview.TimeFrame{Objects: []*tacview.Object{{Id: 1, Deleted: true}}})
+	if _, ok := s.At(1); ok {
+		t.Error("At(1) still found after delete")
+	}
+}
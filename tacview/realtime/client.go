@@ -0,0 +1,185 @@
+// Package realtime implements Tacview's real-time telemetry protocol: a
+// TCP stream (conventionally port 42674) that starts with a short
+// handshake and then carries the same header + "#offset" frame format
+// as an on-disk ACMI recording.
+package realtime
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/NanoTrasen-Inc/jambon/tacview"
+)
+
+// DefaultPort is the conventional Tacview real-time telemetry port.
+const DefaultPort = 42674
+
+// Handshake identifies a client to the host application. Tacview itself
+// accepts an empty Password for anonymous viewers.
+type Handshake struct {
+	LowLevelProtocol string
+	Username         string
+	Password         string
+	HostUsername     string
+}
+
+// Client streams time frames from a Tacview-compatible real-time host.
+type Client struct {
+	conn   net.Conn
+	reader *tacview.Reader
+	frames chan *tacview.TimeFrame
+	errc   chan error
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Dial connects to addr, performs the handshake, and starts streaming
+// frames in the background. Call Frames to consume them.
+func Dial(addr string, hs Handshake) (*Client, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeHandshake(conn, hs); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	reader, err := tacview.NewStreamReader(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	c := &Client{
+		conn:   conn,
+		reader: reader,
+		frames: make(chan *tacview.TimeFrame),
+		errc:   make(chan error, 1),
+	}
+	go c.pump()
+
+	return c, nil
+}
+
+// Header returns the host's ACMI header, received as part of connecting.
+func (c *Client) Header() tacview.Header {
+	return c.reader.Header
+}
+
+// Frames returns the channel time frames are delivered on. It is closed
+// when the connection ends, whether cleanly or not; check Err to find
+// out which.
+func (c *Client) Frames() <-chan *tacview.TimeFrame {
+	return c.frames
+}
+
+// Err returns the error that ended the stream, or nil if Frames closed
+// because the host ended the stream cleanly or Close was called.
+func (c *Client) Err() error {
+	select {
+	case err := <-c.errc:
+		c.errc <- err
+		return err
+	default:
+		return nil
+	}
+}
+
+// Close disconnects from the host.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+
+	return c.conn.Close()
+}
+
+func (c *Client) pump() {
+	defer close(c.frames)
+
+	for {
+		tf, err := c.reader.ReadTimeFrame()
+		if tf != nil {
+			c.frames <- tf
+		}
+		if err != nil {
+			c.mu.Lock()
+			closed := c.closed
+			c.mu.Unlock()
+
+			if err != io.EOF && !closed {
+				c.errc <- err
+			}
+			return
+		}
+	}
+}
+
+func writeHandshake(w io.Writer, hs Handshake) error {
+	lines := []string{
+		"XtraLib.Stream.0",
+		"Tacview.RealTimeTelemetry.0",
+		fmt.Sprintf("LowLevelProtocol=%s", hs.LowLevelProtocol),
+		fmt.Sprintf("Username=%s", hs.Username),
+		fmt.Sprintf("Password=%s", hs.Password),
+		fmt.Sprintf("HostUsername=%s", hs.HostUsername),
+	}
+
+	for _, line := range lines {
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte{0})
+	return err
+}
+
+func readHandshake(r *bufio.Reader) (Handshake, error) {
+	var hs Handshake
+
+	for i := 0; i < 2; i++ {
+		if _, err := r.ReadString('\n'); err != nil {
+			return hs, err
+		}
+	}
+
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return hs, err
+		}
+		if b[0] == 0 {
+			r.Discard(1)
+			return hs, nil
+		}
+
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return hs, err
+		}
+
+		kv := strings.SplitN(strings.TrimSuffix(line, "\n"), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "LowLevelProtocol":
+			hs.LowLevelProtocol = kv[1]
+		case "Username":
+			hs.Username = kv[1]
+		case "Password":
+			hs.Password = kv[1]
+		case "HostUsername":
+			hs.HostUsername = kv[1]
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package realtime
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/NanoTrasen-Inc/jambon/tacview"
+)
+
+func TestDialReturnsBeforeFirstBroadcast(t *testing.T) {
+	header := &tacview.Header{InitialTimeFrame: *tacview.NewTimeFrame()}
+	header.InitialTimeFrame.Objects = append(header.InitialTimeFrame.Objects, &tacview.Object{
+		Id:         0,
+		Properties: []*tacview.Property{{Key: "ReferenceTime", Value: "2020-01-01T00:00:00Z"}},
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	srv := NewServer(header)
+	go srv.Serve(ln, nil)
+
+	dialed := make(chan error, 1)
+	go func() {
+		c, err := Dial(ln.Addr().String(), Handshake{})
+		if err == nil {
+			c.Close()
+		}
+		dialed <- err
+	}()
+
+	select {
+	case err := <-dialed:
+		if err != nil {
+			t.Fatalf("Dial: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Dial blocked waiting for a broadcast that never came")
+	}
+}
+
+func TestClientErrNilAfterClose(t *testing.T) {
+	header := &tacview.Header{InitialTimeFrame: *tacview.NewTimeFrame()}
+	header.InitialTimeFrame.Objects = append(header.InitialTimeFrame.Objects, &tacview.Object{
+		Id:         0,
+		Properties: []*tacview.Property{{Key: "ReferenceTime", Value: "2020-01-01T00:00:00Z"}},
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	srv := NewServer(header)
+	go srv.Serve(ln, nil)
+
+	c, err := Dial(ln.Addr().String(), Handshake{})
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	select {
+	case <-c.Frames():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Frames channel did not close after Close")
+	}
+
+	if err := c.Err(); err != nil {
+		t.Errorf("Err() after Close = %v, want nil", err)
+	}
+}
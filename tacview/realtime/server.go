@@ -0,0 +1,123 @@
+package realtime
+
+import (
+	"bufio"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/NanoTrasen-Inc/jambon/tacview"
+)
+
+const writeTimeout = 5 * time.Second
+
+type Server struct {
+	header *tacview.Header
+
+	mu      sync.Mutex
+	clients map[*serverClient]struct{}
+}
+
+type serverClient struct {
+	conn   net.Conn
+	writer *tacview.Writer
+}
+
+func NewServer(header *tacview.Header) *Server {
+	return &Server{
+		header:  header,
+		clients: make(map[*serverClient]struct{}),
+	}
+}
+
+func (s *Server) Serve(l net.Listener, authenticate func(Handshake) error) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+
+		go s.handle(conn, authenticate)
+	}
+}
+
+// Broadcast multicasts tf to every currently-connected, authenticated
+// client, dropping any client a write fails against. Clients are written
+// to concurrently so one stalled connection can only delay Broadcast by
+// writeTimeout, not by writeTimeout per stalled client.
+func (s *Server) Broadcast(tf *tacview.TimeFrame) {
+	s.mu.Lock()
+	clients := make([]*serverClient, 0, len(s.clients))
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, c := range clients {
+		wg.Add(1)
+		go func(c *serverClient) {
+			defer wg.Done()
+
+			c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+
+			if err := c.writer.WriteTimeFrame(tf); err != nil {
+				s.drop(c)
+				return
+			}
+			if err := c.writer.Flush(); err != nil {
+				s.drop(c)
+			}
+		}(c)
+	}
+	wg.Wait()
+}
+
+func (s *Server) drop(c *serverClient) {
+	c.conn.Close()
+
+	s.mu.Lock()
+	delete(s.clients, c)
+	s.mu.Unlock()
+}
+
+func (s *Server) handle(conn net.Conn, authenticate func(Handshake) error) {
+	hs, err := readHandshake(bufio.NewReader(conn))
+	if err != nil {
+		conn.Close()
+		return
+	}
+
+	if authenticate != nil {
+		if err := authenticate(hs); err != nil {
+			conn.Close()
+			return
+		}
+	}
+
+	writer, err := tacview.NewWriter(conn, s.header)
+	if err != nil {
+		conn.Close()
+		return
+	}
+
+	c := &serverClient{conn: conn, writer: writer}
+
+	s.mu.Lock()
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	var discard [1]byte
+	for {
+		if _, err := conn.Read(discard[:]); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package tacview
+
+import "io"
+
+// ReadTimeFrame reads and returns the next time frame from r, blocking
+// until it has been fully received (i.e. until the following "#" marker
+// arrives, or EOF). Unlike ProcessTimeFrames, it doesn't batch the
+// stream into buffered chunks ahead of parsing, so it works just as well
+// against a live connection as a file; tacview/realtime builds its
+// Client on top of it.
+//
+// As with io.Reader, a non-nil error can come back alongside a fully
+// populated TimeFrame: io.EOF means the frame is the last thing the
+// stream had to offer.
+func (r *Reader) ReadTimeFrame() (*TimeFrame, error) {
+	tf := NewTimeFrame()
+	err := r.readTimeFrame(r.reader, tf, true)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return tf, err
+}
@@ -53,11 +53,21 @@ type Header struct {
 type Reader struct {
 	Header Header
 	reader *bufio.Reader
+	ra     io.ReaderAt
+	index  *Index
+
+	// VerifyChecksums requires single-threaded reads (processes=1).
+	VerifyChecksums bool
+	crcMu           sync.Mutex
+	lastCRC         uint32
 }
 
 type Writer struct {
-	writer *bufio.Writer
-	closer io.Closer
+	writer  *bufio.Writer
+	closer  io.Closer
+	counter *countingWriter
+	index   *Index
+	lastCRC uint32
 }
 
 type TimeFrame struct {
@@ -83,19 +93,28 @@ func NewTimeFrame() *TimeFrame {
 }
 
 func NewWriter(writer io.WriteCloser, header *Header) (*Writer, error) {
+	counter := &countingWriter{w: writer}
 	w := &Writer{
-		writer: bufio.NewWriter(writer),
-		closer: writer,
+		writer:  bufio.NewWriter(counter),
+		closer:  writer,
+		counter: counter,
 	}
 	return w, w.writeHeader(header)
 }
 
 func NewReader(reader io.Reader) (*Reader, error) {
 	r := &Reader{reader: bufio.NewReader(bom.NewReader(reader))}
+	if ra, ok := reader.(io.ReaderAt); ok {
+		r.ra = ra
+	}
 	err := r.readHeader()
 	return r, err
 }
 
+func (w *Writer) Flush() error {
+	return w.writer.Flush()
+}
+
 func (w *Writer) Close() error {
 	err := w.writer.Flush()
 	if err != nil {
@@ -114,9 +133,30 @@ func (w *Writer) writeHeader(header *Header) error {
 }
 
 func (w *Writer) WriteTimeFrame(tf *TimeFrame) error {
+	if w.index != nil {
+		if err := w.writer.Flush(); err != nil {
+			return err
+		}
+		w.index.Entries = append(w.index.Entries, IndexEntry{Offset: tf.Offset, BytePos: w.counter.n})
+	}
 	return tf.Write(w.writer, true)
 }
 
+func (w *Writer) TrackIndex(idx *Index) {
+	w.index = idx
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
 func (h *Header) Write(writer *bufio.Writer) error {
 	_, err := writer.WriteString("FileType=text/acmi/tacview\nFileVersion=2.2\n")
 	if err != nil {
@@ -139,8 +179,7 @@ func (tf *TimeFrame) Get(id uint64) *Object {
 
 func (tf *TimeFrame) Write(writer *bufio.Writer, includeOffset bool) error {
 	if includeOffset {
-		_, err := writer.WriteString(fmt.Sprintf("#%F\n", tf.Offset))
-		if err != nil {
+		if err := tf.writeOffsetLine(writer); err != nil {
 			return err
 		}
 	}
@@ -152,6 +191,11 @@ func (tf *TimeFrame) Write(writer *bufio.Writer, includeOffset bool) error {
 	return nil
 }
 
+func (tf *TimeFrame) writeOffsetLine(writer *bufio.Writer) error {
+	_, err := writer.WriteString(fmt.Sprintf("#%F\n", tf.Offset))
+	return err
+}
+
 func (o *Object) Set(key string, value string) {
 	for _, property := range o.Properties {
 		if property.Key == key {
@@ -309,6 +353,7 @@ func (r *Reader) readTimeFrame(reader *bufio.Reader, timeFrame *TimeFrame, parse
 	}
 
 	timeFrameObjectCache := make(map[uint64]*Object)
+	var body bytes.Buffer
 
 	for {
 		buffer := ""
@@ -322,12 +367,21 @@ func (r *Reader) readTimeFrame(reader *bufio.Reader, timeFrame *TimeFrame, parse
 			break
 		}
 
+		if nextLinePrefix[0] == '/' {
+			if err := r.readChecksumTrailer(reader, timeFrame.Offset, body.Bytes()); err != nil {
+				return err
+			}
+			continue
+		}
+
 		for {
 			line, err := reader.ReadString('\n')
 			if err != nil {
 				return err
 			}
 
+			body.WriteString(line)
+
 			buffer = buffer + strings.TrimSuffix(line, "\n")
 			if !strings.HasSuffix(buffer, "\\") {
 				break
@@ -382,7 +436,7 @@ func (r *Reader) readTimeFrame(reader *bufio.Reader, timeFrame *TimeFrame, parse
 	return nil
 }
 
-func (r *Reader) readHeader() error {
+func (r *Reader) readHeaderPrefix() error {
 	foundFileType := false
 	foundFileVersion := false
 
@@ -408,16 +462,12 @@ func (r *Reader) readHeader() error {
 		}
 
 		if foundFileType && foundFileVersion {
-			break
+			return nil
 		}
 	}
+}
 
-	r.Header.InitialTimeFrame = *NewTimeFrame()
-	err := r.readTimeFrame(r.reader, &r.Header.InitialTimeFrame, false)
-	if err != nil {
-		return err
-	}
-
+func (r *Reader) finishHeader() error {
 	globalObj := r.Header.InitialTimeFrame.Get(0)
 	if globalObj == nil {
 		return fmt.Errorf("No global object found in initial time frame")
@@ -437,3 +487,79 @@ func (r *Reader) readHeader() error {
 
 	return nil
 }
+
+func (r *Reader) readHeader() error {
+	if err := r.readHeaderPrefix(); err != nil {
+		return err
+	}
+
+	r.Header.InitialTimeFrame = *NewTimeFrame()
+	if err := r.readTimeFrame(r.reader, &r.Header.InitialTimeFrame, false); err != nil {
+		return err
+	}
+
+	return r.finishHeader()
+}
+
+// NewStreamReader is like NewReader, but parses the header's trailing
+// declaration as a single global object line instead of peeking ahead
+// to see whether more header lines follow. Over a live connection (see
+// tacview/realtime) nothing else may arrive until the host's first
+// broadcast, so the lookahead NewReader relies on would block forever;
+// streaming transports should use this instead.
+func NewStreamReader(reader io.Reader) (*Reader, error) {
+	r := &Reader{reader: bufio.NewReader(bom.NewReader(reader))}
+	err := r.readStreamHeader()
+	return r, err
+}
+
+func (r *Reader) readStreamHeader() error {
+	if err := r.readHeaderPrefix(); err != nil {
+		return err
+	}
+
+	obj, err := r.readGlobalObjectLine(r.reader)
+	if err != nil {
+		return err
+	}
+
+	r.Header.InitialTimeFrame = *NewTimeFrame()
+	r.Header.InitialTimeFrame.Objects = append(r.Header.InitialTimeFrame.Objects, obj)
+
+	return r.finishHeader()
+}
+
+func (r *Reader) readGlobalObjectLine(reader *bufio.Reader) (*Object, error) {
+	buffer := ""
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		buffer += strings.TrimSuffix(line, "\n")
+		if !strings.HasSuffix(buffer, "\\") {
+			break
+		}
+
+		buffer = buffer[:len(buffer)-1] + "\n"
+	}
+
+	rawLineParts := objectLineRe.FindAllStringSubmatch(buffer, -1)
+	if len(rawLineParts) != 1 {
+		return nil, fmt.Errorf("Failed to parse line: `%v` (%v)", buffer, len(rawLineParts))
+	}
+	lineParts := rawLineParts[0]
+
+	objectId, err := strconv.ParseUint(lineParts[1], 16, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	object := &Object{Id: objectId, Properties: make([]*Property, 0)}
+	if err := r.parseObject(object, lineParts[2]); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
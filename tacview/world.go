@@ -0,0 +1,101 @@
+package tacview
+
+import (
+	"io"
+	"sort"
+)
+
+type World struct {
+	objects map[uint64]*Object
+	offset  float64
+}
+
+func NewWorld() *World {
+	return &World{objects: make(map[uint64]*Object)}
+}
+
+func (w *World) Apply(tf *TimeFrame) {
+	w.offset = tf.Offset
+
+	for _, o := range tf.Objects {
+		if o.Deleted {
+			delete(w.objects, o.Id)
+			continue
+		}
+
+		existing, ok := w.objects[o.Id]
+		if !ok {
+			existing = &Object{Id: o.Id}
+			w.objects[o.Id] = existing
+		}
+
+		for _, p := range o.Properties {
+			existing.Set(p.Key, p.Value)
+		}
+	}
+}
+
+func (w *World) Objects() []*Object {
+	out := make([]*Object, 0, len(w.objects))
+	for _, o := range w.objects {
+		clone := &Object{Id: o.Id, Properties: make([]*Property, len(o.Properties))}
+		for i, p := range o.Properties {
+			clone.Properties[i] = &Property{Key: p.Key, Value: p.Value}
+		}
+		out = append(out, clone)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Id < out[j].Id })
+
+	return out
+}
+
+func (w *World) Snapshot() *TimeFrame {
+	tf := NewTimeFrame()
+	tf.Offset = w.offset
+	tf.Objects = w.Objects()
+	return tf
+}
+
+func (w *Writer) WriteKeyframe(world *World) error {
+	return w.WriteTimeFrame(world.Snapshot())
+}
+
+// Trim always scans from the top rather than using an Index to jump
+// ahead: the keyframe can only be resolved by folding every frame before
+// start, sparse deltas and all, so there's nothing to gain by seeking.
+func (r *Reader) Trim(start, end float64, out *Writer) error {
+	world := NewWorld()
+	keyframeWritten := false
+
+	for {
+		tf, err := r.ReadTimeFrame()
+		if tf != nil {
+			if tf.Offset > end {
+				return nil
+			}
+
+			world.Apply(tf)
+
+			switch {
+			case tf.Offset < start:
+			case !keyframeWritten:
+				if werr := out.WriteKeyframe(world); werr != nil {
+					return werr
+				}
+				keyframeWritten = true
+			default:
+				if werr := out.WriteTimeFrame(tf); werr != nil {
+					return werr
+				}
+			}
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
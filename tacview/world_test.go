@@ -0,0 +1,126 @@
+package tacview
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestWorldApplyAndObjects(t *testing.T) {
+	w := NewWorld()
+
+	w.Apply(&TimeFrame{Offset: 1, Objects: []*Object{
+		{Id: 1, Properties: []*Property{{Key: "Name", Value: "F-16"}, {Key: "T", Value: "1|2|3"}}},
+	}})
+	w.Apply(&TimeFrame{Offset: 2, Objects: []*Object{
+		{Id: 1, Properties: []*Property{{Key: "T", Value: "4|5|6"}}},
+		{Id: 2, Properties: []*Property{{Key: "Name", Value: "F-18"}}},
+	}})
+
+	objects := w.Objects()
+	if len(objects) != 2 {
+		t.Fatalf("got %d objects, want 2", len(objects))
+	}
+
+	obj1 := objects[0]
+	if obj1.Id != 1 {
+		t.Fatalf("objects[0].Id = %v, want 1", obj1.Id)
+	}
+	if got := obj1.Get("Name").Value; got != "F-16" {
+		t.Errorf("Name = %q, want F-16 (merged, not overwritten by later frame)", got)
+	}
+	if got := obj1.Get("T").Value; got != "4|5|6" {
+		t.Errorf("T = %q, want 4|5|6 (latest value)", got)
+	}
+
+	w.Apply(&TimeFrame{Offset: 3, Objects: []*Object{{Id: 1, Deleted: true}}})
+	objects = w.Objects()
+	if len(objects) != 1 || objects[0].Id != 2 {
+		t.Fatalf("after deleting object 1, got %+v, want only object 2", objects)
+	}
+}
+
+func TestWorldObjectsReturnsIndependentClones(t *testing.T) {
+	w := NewWorld()
+	w.Apply(&TimeFrame{Offset: 1, Objects: []*Object{{Id: 1, Properties: []*Property{{Key: "Name", Value: "F-16"}}}}})
+
+	objects := w.Objects()
+	objects[0].Set("Name", "mutated")
+
+	if got := w.Objects()[0].Get("Name").Value; got != "F-16" {
+		t.Errorf("mutating a returned clone affected the world: Name = %q, want F-16", got)
+	}
+}
+
+func TestReaderTrim(t *testing.T) {
+	header := &Header{InitialTimeFrame: *NewTimeFrame()}
+	header.InitialTimeFrame.Objects = append(header.InitialTimeFrame.Objects, &Object{
+		Id:         0,
+		Properties: []*Property{{Key: "ReferenceTime", Value: "2020-01-01T00:00:00Z"}},
+	})
+
+	var buf bytes.Buffer
+	w, err := NewWriter(nopWriteCloser{&buf}, header)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	frames := []*TimeFrame{
+		{Offset: 1, Objects: []*Object{{Id: 1, Properties: []*Property{{Key: "Name", Value: "F-16"}, {Key: "T", Value: "1|2|3"}}}}},
+		{Offset: 2, Objects: []*Object{{Id: 1, Properties: []*Property{{Key: "T", Value: "4|5|6"}}}}},
+		{Offset: 3, Objects: []*Object{{Id: 1, Properties: []*Property{{Key: "T", Value: "7|8|9"}}}}},
+		{Offset: 4, Objects: []*Object{{Id: 1, Properties: []*Property{{Key: "T", Value: "10|11|12"}}}}},
+	}
+	for _, tf := range frames {
+		if err := w.WriteTimeFrame(tf); err != nil {
+			t.Fatalf("WriteTimeFrame: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	var out bytes.Buffer
+	outWriter, err := NewWriter(nopWriteCloser{&out}, header)
+	if err != nil {
+		t.Fatalf("NewWriter (out): %v", err)
+	}
+	if err := r.Trim(2, 3, outWriter); err != nil {
+		t.Fatalf("Trim: %v", err)
+	}
+	if err := outWriter.Close(); err != nil {
+		t.Fatalf("Close (out): %v", err)
+	}
+
+	trimmed, err := NewReader(&out)
+	if err != nil {
+		t.Fatalf("NewReader (trimmed): %v", err)
+	}
+
+	keyframe, err := trimmed.ReadTimeFrame()
+	if err != nil {
+		t.Fatalf("ReadTimeFrame (keyframe): %v", err)
+	}
+	if keyframe.Offset != 2 {
+		t.Errorf("keyframe offset = %v, want 2", keyframe.Offset)
+	}
+	if got := keyframe.Objects[0].Get("Name").Value; got != "F-16" {
+		t.Errorf("keyframe should fold in properties from before start: Name = %q, want F-16", got)
+	}
+	if got := keyframe.Objects[0].Get("T").Value; got != "4|5|6" {
+		t.Errorf("keyframe T = %q, want 4|5|6", got)
+	}
+
+	delta, err := trimmed.ReadTimeFrame()
+	if err != nil && err != io.EOF {
+		t.Fatalf("ReadTimeFrame (delta): %v", err)
+	}
+	if delta.Offset != 3 {
+		t.Errorf("delta offset = %v, want 3", delta.Offset)
+	}
+}
@@ -0,0 +1,126 @@
+package tacview
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const zipEntryName = "track.txt.acmi"
+
+var zipMagic = []byte{'P', 'K', 0x03, 0x04}
+
+func NewZipReader(ra io.ReaderAt, size int64) (*Reader, io.Closer, error) {
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entry *zip.File
+	for _, f := range zr.File {
+		if strings.HasSuffix(f.Name, ".txt.acmi") {
+			entry = f
+			break
+		}
+	}
+	if entry == nil && len(zr.File) == 1 {
+		entry = zr.File[0]
+	}
+	if entry == nil {
+		return nil, nil, fmt.Errorf("No .txt.acmi entry found in zip archive")
+	}
+
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := NewReader(rc)
+	if err != nil {
+		rc.Close()
+		return nil, nil, err
+	}
+
+	return r, rc, nil
+}
+
+type zipWriteCloser struct {
+	io.Writer
+	zw *zip.Writer
+}
+
+func (z *zipWriteCloser) Close() error {
+	return z.zw.Close()
+}
+
+func NewZipWriter(w io.Writer, header *Header) (*Writer, error) {
+	zw := zip.NewWriter(w)
+
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name:   zipEntryName,
+		Method: zip.Deflate,
+	})
+	if err != nil {
+		zw.Close()
+		return nil, err
+	}
+
+	writer, err := NewWriter(&zipWriteCloser{fw, zw}, header)
+	if err != nil {
+		zw.Close()
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+type multiCloser []io.Closer
+
+func (m multiCloser) Close() error {
+	var err error
+	for _, c := range m {
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+func Open(path string) (*Reader, io.Closer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	magic := make([]byte, len(zipMagic))
+	if _, err := f.ReadAt(magic, 0); err != nil && err != io.EOF {
+		f.Close()
+		return nil, nil, err
+	}
+
+	if bytes.Equal(magic, zipMagic) {
+		r, entry, err := NewZipReader(f, info.Size())
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return r, multiCloser{entry, f}, nil
+	}
+
+	r, err := NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return r, f, nil
+}
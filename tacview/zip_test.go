@@ -0,0 +1,43 @@
+package tacview
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestZipWriterReaderRoundTrip(t *testing.T) {
+	header := &Header{InitialTimeFrame: *NewTimeFrame()}
+	header.InitialTimeFrame.Objects = append(header.InitialTimeFrame.Objects, &Object{
+		Id:         0,
+		Properties: []*Property{{Key: "ReferenceTime", Value: "2020-01-01T00:00:00Z"}},
+	})
+
+	var buf bytes.Buffer
+	w, err := NewZipWriter(&buf, header)
+	if err != nil {
+		t.Fatalf("NewZipWriter: %v", err)
+	}
+
+	tf := &TimeFrame{Offset: 1, Objects: []*Object{{Id: 1, Properties: []*Property{{Key: "T", Value: "1|2|3"}}}}}
+	if err := w.WriteTimeFrame(tf); err != nil {
+		t.Fatalf("WriteTimeFrame: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	r, closer, err := NewZipReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("NewZipReader: %v", err)
+	}
+	defer closer.Close()
+
+	got, err := r.ReadTimeFrame()
+	if err != nil && err != io.EOF {
+		t.Fatalf("ReadTimeFrame: %v", err)
+	}
+	if got.Offset != tf.Offset {
+		t.Errorf("got offset %v, want %v", got.Offset, tf.Offset)
+	}
+}